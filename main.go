@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/pflag"
 	"github.com/ueokande/kubectl-evict/pkg/cmd"
+	"github.com/ueokande/kubectl-evict/pkg/cmd/controller"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -12,8 +16,15 @@ func main() {
 	flags := pflag.NewFlagSet("kubectl-evict", pflag.ExitOnError)
 	pflag.CommandLine = flags
 
-	root := cmd.NewCmdEvict(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
-	if err := root.Execute(); err != nil {
+	streams := genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+
+	root := cmd.NewCmdEvict(streams)
+	root.AddCommand(controller.NewCmdController(streams))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := root.ExecuteContext(ctx); err != nil {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ueokande/kubectl-evict/pkg/cmd"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	controllerUsageStr = "controller"
+
+	// TaintedTimestampAnnotation marks when a pod was flagged for remediation; pods carrying
+	// it are evicted once that timestamp is older than --max-age.
+	TaintedTimestampAnnotation = "kubectl-evict/tainted-timestamp"
+	// PreventEvictionAnnotation is a break-glass annotation that exempts a pod from eviction
+	// by the controller regardless of TaintedTimestampAnnotation.
+	PreventEvictionAnnotation = "kubectl-evict/prevent-eviction"
+)
+
+type ControllerOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+
+	MaxAge time.Duration
+	Reason string
+
+	LeaderElect        bool
+	LeaseLockName      string
+	LeaseLockNamespace string
+
+	genericclioptions.IOStreams
+}
+
+func NewControllerOptions(streams genericclioptions.IOStreams) *ControllerOptions {
+	return &ControllerOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+
+		MaxAge: 15 * time.Minute,
+		Reason: "StuckOrTainted",
+
+		LeaseLockName:      "kubectl-evict-controller",
+		LeaseLockNamespace: "default",
+
+		IOStreams: streams,
+	}
+}
+
+// NewCmdController provides a cobra command that runs kubectl-evict as a long-running,
+// in-cluster controller: it watches all pods through a shared informer and evicts the ones
+// matching the tainted-timestamp/prevent-eviction predicate, instead of acting once on the
+// resources given on the command line.
+func NewCmdController(streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewControllerOptions(streams)
+
+	c := &cobra.Command{
+		Use:          controllerUsageStr,
+		Short:        "Run as an in-cluster controller that evicts tainted pods on a schedule",
+		SilenceUsage: true,
+		RunE: func(cc *cobra.Command, args []string) error {
+			return o.Run(cc.Context())
+		},
+	}
+
+	c.Flags().DurationVar(&o.MaxAge, "max-age", o.MaxAge, "Evict pods whose tainted-timestamp annotation is older than this.")
+	c.Flags().StringVar(&o.Reason, "reason", o.Reason, "Reason recorded on the Event emitted for each eviction.")
+	c.Flags().BoolVar(&o.LeaderElect, "leader-elect", false, "Run with leader election so multiple replicas of the controller are safe.")
+	c.Flags().StringVar(&o.LeaseLockName, "lease-lock-name", o.LeaseLockName, "Name of the Lease used for leader election.")
+	c.Flags().StringVar(&o.LeaseLockNamespace, "lease-lock-namespace", o.LeaseLockNamespace, "Namespace of the Lease used for leader election.")
+
+	o.configFlags.AddFlags(c.PersistentFlags())
+
+	return c
+}
+
+// Run starts the controller, optionally gated behind leader election so that only one of
+// several replicas is ever reconciling at a time.
+func (o *ControllerOptions) Run(ctx context.Context) error {
+	clientConfig, err := o.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	client, err := kubernetes.NewForConfig(clientConfig)
+	if err != nil {
+		return err
+	}
+
+	if !o.LeaderElect {
+		return o.runController(ctx, client)
+	}
+
+	id, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      o.LeaseLockName,
+			Namespace: o.LeaseLockNamespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: id,
+		},
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				if err := o.runController(ctx, client); err != nil {
+					fmt.Fprintf(o.ErrOut, "controller stopped: %v\n", err)
+				}
+			},
+			OnStoppedLeading: func() {
+				fmt.Fprintf(o.Out, "%s stopped leading, shutting down\n", id)
+			},
+		},
+	})
+
+	return nil
+}
+
+// runController watches all pods and evicts the ones matching shouldEvict until ctx is done.
+func (o *ControllerOptions) runController(ctx context.Context, client kubernetes.Interface) error {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(metav1.NamespaceAll)})
+	defer broadcaster.Shutdown()
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "kubectl-evict-controller"})
+
+	eviction := cmd.NewEvictClient(client)
+
+	factory := informers.NewSharedInformerFactory(client, 10*time.Minute)
+	podInformer := factory.Core().V1().Pods().Informer()
+
+	onPodChanged := func(obj interface{}) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok || !o.shouldEvict(pod) {
+			return
+		}
+		if err := eviction.EvictPod(ctx, *pod, &metav1.DeleteOptions{}); err != nil {
+			utilruntime.HandleError(fmt.Errorf("evict pod %s/%s: %v", pod.Namespace, pod.Name, err))
+			return
+		}
+		recorder.Eventf(pod, corev1.EventTypeNormal, o.Reason, "evicted by kubectl-evict controller")
+		fmt.Fprintf(o.Out, "pod %s/%s evicted\n", pod.Namespace, pod.Name)
+	}
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: onPodChanged,
+		UpdateFunc: func(_, newObj interface{}) {
+			onPodChanged(newObj)
+		},
+	})
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// shouldEvict reports whether pod carries a tainted-timestamp annotation older than --max-age
+// and has not been marked with the prevent-eviction break-glass annotation.
+func (o *ControllerOptions) shouldEvict(pod *corev1.Pod) bool {
+	if _, ok := pod.Annotations[PreventEvictionAnnotation]; ok {
+		return false
+	}
+
+	ts, ok := pod.Annotations[TaintedTimestampAnnotation]
+	if !ok {
+		return false
+	}
+
+	taintedAt, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("pod %s/%s: invalid %s annotation: %v", pod.Namespace, pod.Name, TaintedTimestampAnnotation, err))
+		return false
+	}
+
+	return time.Since(taintedAt) > o.MaxAge
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestShouldEvict(t *testing.T) {
+	o := &ControllerOptions{MaxAge: 15 * time.Minute}
+
+	pod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "web-1",
+				Annotations: annotations,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "no tainted-timestamp annotation",
+			pod:  pod(nil),
+			want: false,
+		},
+		{
+			name: "tainted more recently than --max-age",
+			pod: pod(map[string]string{
+				TaintedTimestampAnnotation: time.Now().Add(-time.Minute).Format(time.RFC3339),
+			}),
+			want: false,
+		},
+		{
+			name: "tainted longer ago than --max-age",
+			pod: pod(map[string]string{
+				TaintedTimestampAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+			}),
+			want: true,
+		},
+		{
+			name: "prevent-eviction annotation overrides an old tainted-timestamp",
+			pod: pod(map[string]string{
+				TaintedTimestampAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+				PreventEvictionAnnotation:  "",
+			}),
+			want: false,
+		},
+		{
+			name: "invalid tainted-timestamp is treated as not tainted",
+			pod: pod(map[string]string{
+				TaintedTimestampAnnotation: "not-a-timestamp",
+			}),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := o.shouldEvict(tt.pod); got != tt.want {
+				t.Errorf("shouldEvict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
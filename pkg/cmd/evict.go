@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/kubernetes"
@@ -42,12 +47,31 @@ var (
 	evictUsageErrStr = fmt.Sprintf("expected '%s'.\nPOD or TYPE/NAME is a required argument for the evict command", evictUsageStr)
 )
 
+// mirrorPodAnnotationKey is set by the kubelet on the static pods it mirrors into the API.
+const mirrorPodAnnotationKey = "kubernetes.io/config.mirror"
+
 type EvictOptions struct {
 	configFlags *genericclioptions.ConfigFlags
 
 	GracePeriodSeconds int64
 	DryRun             bool
 
+	Force              bool
+	IgnoreDaemonsets   bool
+	DeleteEmptyDirData bool
+	SkipMirrorPods     bool
+
+	Parallelism int
+	Timeout     time.Duration
+	PodSelector string
+
+	ForceDelete     bool
+	DisableEviction bool
+
+	Wait bool
+
+	PrintFlags *genericclioptions.PrintFlags
+
 	ResourceArg string
 	Selector    string
 	Object      runtime.Object
@@ -61,6 +85,11 @@ func NewEvictOptions(streams genericclioptions.IOStreams) *EvictOptions {
 	return &EvictOptions{
 		configFlags: configFlags,
 
+		SkipMirrorPods: true,
+		Parallelism:    5,
+
+		PrintFlags: genericclioptions.NewPrintFlags("evicted"),
+
 		IOStreams: streams,
 	}
 }
@@ -89,7 +118,18 @@ func NewCmdEvict(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd.Flags().StringVarP(&o.Selector, "selector", "l", o.Selector, "Selector (label query) to filter on.")
 	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "If true, submit server-side request without persisting the resource.")
 	cmd.Flags().Int64Var(&o.GracePeriodSeconds, "grace-period", -1, "Period of time in seconds given to the resource to terminate gracefully. Ignored if negative.")
-
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Evict pods even if they are not managed by a controller.")
+	cmd.Flags().BoolVar(&o.IgnoreDaemonsets, "ignore-daemonsets", false, "Ignore DaemonSet-managed pods instead of aborting.")
+	cmd.Flags().BoolVar(&o.DeleteEmptyDirData, "delete-emptydir-data", false, "Evict pods even if they use emptyDir volumes, which will be deleted.")
+	cmd.Flags().BoolVar(&o.SkipMirrorPods, "skip-mirror-pods", true, "Skip static/mirror pods instead of attempting to evict them.")
+	cmd.Flags().IntVar(&o.Parallelism, "parallelism", o.Parallelism, "Number of pods to evict concurrently.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 0, "The length of time to wait before giving up on a single pod, zero means no time limit; PodDisruptionBudget retries still stop once their backoff schedule is exhausted.")
+	cmd.Flags().StringVar(&o.PodSelector, "pod-selector", "", "Label selector to further filter the pods evicted from a node or controller target.")
+	cmd.Flags().BoolVar(&o.ForceDelete, "force-delete", false, "Delete pods directly with a zero grace period when the eviction API cannot evict them.")
+	cmd.Flags().BoolVar(&o.DisableEviction, "disable-eviction", false, "Force using delete rather than evict, bypassing the eviction API and any PodDisruptionBudgets.")
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "Include the pod's phase in the result: Gone once the pod is deleted or replaced, otherwise the last phase observed before giving up. The phase the pod terminated in is not recorded.")
+
+	o.PrintFlags.AddFlags(cmd)
 	o.configFlags.AddFlags(cmd.PersistentFlags())
 
 	return cmd
@@ -158,6 +198,16 @@ func (o *EvictOptions) RunEvict(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if o.PodSelector != "" {
+		pods, err = filterPodsBySelector(pods, o.PodSelector)
+		if err != nil {
+			return err
+		}
+	}
+	pods, skipped, err := o.filterPods(pods)
+	if err != nil {
+		return err
+	}
 
 	opts := new(metav1.DeleteOptions)
 	if o.GracePeriodSeconds >= 0 {
@@ -167,20 +217,217 @@ func (o *EvictOptions) RunEvict(ctx context.Context) error {
 		opts.DryRun = []string{metav1.DryRunAll}
 	}
 
-	verb := "evicted"
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+	useTable := o.PrintFlags.OutputFormat == nil || *o.PrintFlags.OutputFormat == ""
+
+	dryRunSuffix := ""
 	if o.DryRun {
-		verb = "evicted (dry-run)"
+		dryRunSuffix = " (dry-run)"
+	}
+
+	total := len(pods)
+	var (
+		mu   sync.Mutex
+		done int
+	)
+	print := func(result EvictResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if useTable {
+			if result.Error != "" {
+				fmt.Fprintf(o.ErrOut, "pod %s/%s %s: %s\n", result.Namespace, result.Name, result.Action, result.Error)
+				return
+			}
+			done++
+			fmt.Fprintf(o.Out, "pod %s/%s %s%s (%d/%d evicted)\n", result.Namespace, result.Name, result.Action, dryRunSuffix, done, total)
+			return
+		}
+		u, uerr := result.toUnstructured()
+		if uerr != nil {
+			fmt.Fprintf(o.ErrOut, "pod %s/%s: %v\n", result.Namespace, result.Name, uerr)
+			return
+		}
+		if err := printer.PrintObj(u, o.Out); err != nil {
+			fmt.Fprintf(o.ErrOut, "pod %s/%s: %v\n", result.Namespace, result.Name, err)
+		}
+	}
+
+	printSkipped := func(result EvictResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if useTable {
+			fmt.Fprintf(o.Out, "pod %s/%s %s: %s\n", result.Namespace, result.Name, result.Action, result.Reason)
+			return
+		}
+		u, uerr := result.toUnstructured()
+		if uerr != nil {
+			fmt.Fprintf(o.ErrOut, "pod %s/%s: %v\n", result.Namespace, result.Name, uerr)
+			return
+		}
+		if err := printer.PrintObj(u, o.Out); err != nil {
+			fmt.Fprintf(o.ErrOut, "pod %s/%s: %v\n", result.Namespace, result.Name, err)
+		}
+	}
+
+	for _, result := range skipped {
+		printSkipped(result)
 	}
 
 	eviction := NewEvictClient(api)
+
+	var (
+		errs []error
+		sem  = make(chan struct{}, o.Parallelism)
+		wg   sync.WaitGroup
+	)
 	for _, pod := range pods {
-		err := eviction.EvictPod(ctx, pod, opts)
+		pod := pod
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			podCtx := ctx
+			if o.Timeout > 0 {
+				var cancel context.CancelFunc
+				podCtx, cancel = context.WithTimeout(ctx, o.Timeout)
+				defer cancel()
+			}
+
+			result := EvictResult{Namespace: pod.Namespace, Name: pod.Name, UID: string(pod.UID)}
+
+			action, err := o.evictPod(podCtx, eviction, api, pod, opts)
+			result.Action = action
+			if err == nil && !o.DryRun {
+				var phase string
+				phase, err = waitForPodGone(podCtx, api.CoreV1(), pod)
+				if o.Wait {
+					result.Phase = phase
+				}
+			}
+			if err != nil {
+				result.Action = "failed"
+				result.Error = err.Error()
+			}
+
+			print(result)
+
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("pod %s/%s: %v", pod.Namespace, pod.Name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// evictPod issues a single eviction for pod, retrying with exponential backoff while the API
+// server reports a PodDisruptionBudget violation (HTTP 429 TooManyRequests), the same way
+// kubectl drain waits out PDBs instead of failing on the first rejection. When the eviction
+// subresource is unavailable for pod (e.g. static/mirror pods), or the retries above are
+// exhausted and --force-delete is set, it falls back to deleting the pod directly and reports
+// which mechanism was ultimately used ("evicted" or "deleted").
+func (o *EvictOptions) evictPod(ctx context.Context, client Client, api kubernetes.Interface, pod corev1.Pod, opts *metav1.DeleteOptions) (string, error) {
+	if o.DisableEviction {
+		return deletePod(ctx, api, pod, opts, o.ForceDelete)
+	}
+
+	backoff := wait.Backoff{
+		Duration: 1 * time.Second,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    30,
+		Cap:      30 * time.Second,
+	}
+	if o.DryRun {
+		// A dry-run eviction never actually removes the pod, so it never frees up the PDB's
+		// disruption budget between attempts either: there is nothing for a retry to wait
+		// out, so report the first PDB rejection instead of busy-waiting the full schedule.
+		backoff.Steps = 1
+	}
+
+	var lastErr error
+	var action string
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		a, err := client.EvictOrDelete(ctx, pod, opts, o.ForceDelete)
+		switch {
+		case err == nil:
+			action = a
+			return true, nil
+		case apierrors.IsTooManyRequests(err):
+			lastErr = err
+			return false, nil
+		default:
+			return false, err
+		}
+	})
+	// wait.ExponentialBackoff reports its own step/cap budget being exhausted as
+	// wait.ErrWaitTimeout, but a shorter-lived --timeout context expires with
+	// context.DeadlineExceeded instead; both mean the same thing here: we gave up waiting out
+	// the PDB, and should fall back to deleting if --force-delete was requested.
+	gaveUp := errors.Is(err, wait.ErrWaitTimeout) || errors.Is(err, context.DeadlineExceeded)
+	switch {
+	case err == nil:
+		return action, nil
+	case gaveUp && o.ForceDelete:
+		return deletePod(ctx, api, pod, opts, true)
+	case gaveUp:
+		if lastErr == nil {
+			lastErr = err
+		}
+		return "", fmt.Errorf("gave up waiting out PodDisruptionBudget: %v", lastErr)
+	default:
+		return "", err
+	}
+}
+
+// waitForPodGone polls the API until the pod is deleted or replaced by a new pod with a
+// different UID, so callers can tell when an eviction has actually taken effect. It returns the
+// last observed phase, or "Gone" once the pod has disappeared.
+func waitForPodGone(ctx context.Context, api corev1client.CoreV1Interface, pod corev1.Pod) (string, error) {
+	phase := string(pod.Status.Phase)
+	err := wait.PollImmediateUntil(2*time.Second, func() (bool, error) {
+		got, err := api.Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			phase = "Gone"
+			return true, nil
+		}
 		if err != nil {
-			return err
+			return false, err
+		}
+		if got.UID != pod.UID {
+			phase = "Gone"
+			return true, nil
 		}
-		fmt.Fprintf(o.Out, "pod %s/%s %s\n", pod.Namespace, pod.Name, verb)
+		phase = string(got.Status.Phase)
+		return false, nil
+	}, ctx.Done())
+	return phase, err
+}
+
+func filterPodsBySelector(pods []corev1.Pod, selector string) ([]corev1.Pod, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --pod-selector: %v", err)
 	}
-	return nil
+
+	var filtered []corev1.Pod
+	for _, pod := range pods {
+		if sel.Matches(labels.Set(pod.Labels)) {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered, nil
 }
 
 func podsForObject(ctx context.Context, api corev1client.CoreV1Interface, object runtime.Object) ([]corev1.Pod, error) {
@@ -212,6 +459,73 @@ func podsForObject(ctx context.Context, api corev1client.CoreV1Interface, object
 	return info.Items, nil
 }
 
+// filterPods applies the same safety checks kubectl drain uses before evicting a pod: mirror
+// pods are skipped unless --skip-mirror-pods=false, DaemonSet-managed pods are skipped only
+// with --ignore-daemonsets (otherwise they abort the command), pods using an emptyDir volume
+// require --delete-emptydir-data, and pods without a controller require --force. It returns the
+// pods still eligible for eviction alongside a result for every pod it skipped outright.
+func (o *EvictOptions) filterPods(pods []corev1.Pod) ([]corev1.Pod, []EvictResult, error) {
+	var filtered []corev1.Pod
+	var skipped []EvictResult
+	var daemonSetPods []string
+	var errs []error
+
+	for _, pod := range pods {
+		if _, ok := pod.Annotations[mirrorPodAnnotationKey]; ok {
+			if o.SkipMirrorPods {
+				skipped = append(skipped, skippedResult(pod, "mirror pod"))
+				continue
+			}
+		}
+
+		if ownerRef := metav1.GetControllerOf(&pod); ownerRef != nil {
+			if ownerRef.Kind == "DaemonSet" {
+				if !o.IgnoreDaemonsets {
+					daemonSetPods = append(daemonSetPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+					continue
+				}
+				skipped = append(skipped, skippedResult(pod, "DaemonSet-managed pod"))
+				continue
+			}
+		} else if !o.Force {
+			errs = append(errs, fmt.Errorf("pod %s/%s: no controller managing this pod, use --force to evict anyway", pod.Namespace, pod.Name))
+			continue
+		}
+
+		if !o.DeleteEmptyDirData && hasEmptyDirVolume(pod) {
+			errs = append(errs, fmt.Errorf("pod %s/%s: uses an emptyDir volume, use --delete-emptydir-data to evict anyway", pod.Namespace, pod.Name))
+			continue
+		}
+
+		filtered = append(filtered, pod)
+	}
+
+	if len(daemonSetPods) > 0 {
+		errs = append(errs, fmt.Errorf("cannot evict DaemonSet-managed pods without --ignore-daemonsets: %v", daemonSetPods))
+	}
+
+	return filtered, skipped, utilerrors.NewAggregate(errs)
+}
+
+func skippedResult(pod corev1.Pod, reason string) EvictResult {
+	return EvictResult{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		UID:       string(pod.UID),
+		Action:    "skipped",
+		Reason:    reason,
+	}
+}
+
+func hasEmptyDirVolume(pod corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func selectorsForObject(object runtime.Object) (namespace string, labelsel labels.Selector, fieldsel fields.Selector, err error) {
 	switch t := object.(type) {
 	case *appsv1.ReplicaSet:
@@ -6,6 +6,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
@@ -17,7 +18,33 @@ const (
 )
 
 type Client interface {
-	EvictPod(ctx context.Context, pod corev1.Pod) error
+	EvictPod(ctx context.Context, pod corev1.Pod, opts *metav1.DeleteOptions) error
+
+	// EvictOrDelete evicts pod through the eviction subresource, falling back to a direct
+	// delete when the eviction subresource is unavailable for pod (e.g. static/mirror pods,
+	// which have no eviction handler and report MethodNotAllowed or NotFound). forceDelete
+	// makes the fallback delete use a zero grace period, as with --force-delete.
+	EvictOrDelete(ctx context.Context, pod corev1.Pod, opts *metav1.DeleteOptions, forceDelete bool) (action string, err error)
+}
+
+// isEvictionUnavailable reports whether err indicates that the eviction subresource does not
+// exist for the target pod, rather than a transient or policy rejection.
+func isEvictionUnavailable(err error) bool {
+	return apierrors.IsMethodNotSupported(err) || apierrors.IsNotFound(err)
+}
+
+// deletePod deletes pod directly through the core API, used both for the --disable-eviction
+// path and as the fallback when the eviction subresource rejects pod outright.
+func deletePod(ctx context.Context, clientset kubernetes.Interface, pod corev1.Pod, opts *metav1.DeleteOptions, forceDelete bool) (string, error) {
+	deleteOpts := opts.DeepCopy()
+	if forceDelete {
+		zero := int64(0)
+		deleteOpts.GracePeriodSeconds = &zero
+	}
+	if err := clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, *deleteOpts); err != nil {
+		return "", err
+	}
+	return "deleted", nil
 }
 
 func evictGroupVersion(clientset kubernetes.Interface) schema.GroupVersion {
@@ -49,26 +76,50 @@ type ClientV1 struct {
 	client kubernetes.Interface
 }
 
-func (c *ClientV1) EvictPod(ctx context.Context, pod corev1.Pod) error {
+func (c *ClientV1) EvictPod(ctx context.Context, pod corev1.Pod, opts *metav1.DeleteOptions) error {
 	eviction := &policyv1.Eviction{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pod.Name,
 			Namespace: pod.Namespace,
 		},
+		DeleteOptions: opts,
 	}
-	return c.client.PolicyV1().Evictions(eviction.Namespace).Evict(context.TODO(), eviction)
+	return c.client.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+}
+
+func (c *ClientV1) EvictOrDelete(ctx context.Context, pod corev1.Pod, opts *metav1.DeleteOptions, forceDelete bool) (string, error) {
+	err := c.EvictPod(ctx, pod, opts)
+	if err == nil {
+		return "evicted", nil
+	}
+	if !isEvictionUnavailable(err) {
+		return "", err
+	}
+	return deletePod(ctx, c.client, pod, opts, forceDelete)
 }
 
 type ClientV1beta1 struct {
 	client kubernetes.Interface
 }
 
-func (c *ClientV1beta1) EvictPod(ctx context.Context, pod corev1.Pod) error {
+func (c *ClientV1beta1) EvictPod(ctx context.Context, pod corev1.Pod, opts *metav1.DeleteOptions) error {
 	eviction := &policyv1beta1.Eviction{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pod.Name,
 			Namespace: pod.Namespace,
 		},
+		DeleteOptions: opts,
+	}
+	return c.client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+}
+
+func (c *ClientV1beta1) EvictOrDelete(ctx context.Context, pod corev1.Pod, opts *metav1.DeleteOptions, forceDelete bool) (string, error) {
+	err := c.EvictPod(ctx, pod, opts)
+	if err == nil {
+		return "evicted", nil
+	}
+	if !isEvictionUnavailable(err) {
+		return "", err
 	}
-	return c.client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(context.TODO(), eviction)
+	return deletePod(ctx, c.client, pod, opts, forceDelete)
 }
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func withController(pod corev1.Pod, kind, name string) corev1.Pod {
+	t := true
+	pod.OwnerReferences = append(pod.OwnerReferences, metav1.OwnerReference{
+		APIVersion: appsv1.SchemeGroupVersion.String(),
+		Kind:       kind,
+		Name:       name,
+		Controller: &t,
+	})
+	return pod
+}
+
+func TestFilterPods(t *testing.T) {
+	basePod := func(name string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+
+	tests := []struct {
+		name         string
+		opts         EvictOptions
+		pods         []corev1.Pod
+		wantFiltered []string
+		wantSkipped  []string
+		wantErr      bool
+	}{
+		{
+			name: "plain pod with a controller passes through",
+			opts: EvictOptions{SkipMirrorPods: true},
+			pods: []corev1.Pod{
+				withController(basePod("web-1"), "ReplicaSet", "web"),
+			},
+			wantFiltered: []string{"web-1"},
+		},
+		{
+			name: "mirror pod is skipped when SkipMirrorPods is set",
+			opts: EvictOptions{SkipMirrorPods: true},
+			pods: []corev1.Pod{
+				func() corev1.Pod {
+					pod := withController(basePod("static-1"), "Node", "worker-1")
+					pod.Annotations = map[string]string{mirrorPodAnnotationKey: ""}
+					return pod
+				}(),
+			},
+			wantSkipped: []string{"static-1"},
+		},
+		{
+			name: "mirror pod is evicted when SkipMirrorPods is false",
+			opts: EvictOptions{SkipMirrorPods: false},
+			pods: []corev1.Pod{
+				func() corev1.Pod {
+					pod := withController(basePod("static-1"), "Node", "worker-1")
+					pod.Annotations = map[string]string{mirrorPodAnnotationKey: ""}
+					return pod
+				}(),
+			},
+			wantFiltered: []string{"static-1"},
+		},
+		{
+			name: "DaemonSet pod aborts without --ignore-daemonsets",
+			opts: EvictOptions{SkipMirrorPods: true},
+			pods: []corev1.Pod{
+				withController(basePod("ds-1"), "DaemonSet", "node-exporter"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "DaemonSet pod is skipped with --ignore-daemonsets",
+			opts: EvictOptions{SkipMirrorPods: true, IgnoreDaemonsets: true},
+			pods: []corev1.Pod{
+				withController(basePod("ds-1"), "DaemonSet", "node-exporter"),
+			},
+			wantSkipped: []string{"ds-1"},
+		},
+		{
+			name: "pod without a controller aborts without --force",
+			opts: EvictOptions{SkipMirrorPods: true},
+			pods: []corev1.Pod{
+				basePod("bare-1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod without a controller passes through with --force",
+			opts: EvictOptions{SkipMirrorPods: true, Force: true},
+			pods: []corev1.Pod{
+				basePod("bare-1"),
+			},
+			wantFiltered: []string{"bare-1"},
+		},
+		{
+			name: "pod with an emptyDir volume aborts without --delete-emptydir-data",
+			opts: EvictOptions{SkipMirrorPods: true},
+			pods: []corev1.Pod{
+				func() corev1.Pod {
+					pod := withController(basePod("cache-1"), "ReplicaSet", "cache")
+					pod.Spec.Volumes = []corev1.Volume{
+						{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					}
+					return pod
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod with an emptyDir volume passes through with --delete-emptydir-data",
+			opts: EvictOptions{SkipMirrorPods: true, DeleteEmptyDirData: true},
+			pods: []corev1.Pod{
+				func() corev1.Pod {
+					pod := withController(basePod("cache-1"), "ReplicaSet", "cache")
+					pod.Spec.Volumes = []corev1.Volume{
+						{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					}
+					return pod
+				}(),
+			},
+			wantFiltered: []string{"cache-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, skipped, err := tt.opts.filterPods(tt.pods)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filterPods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			gotFiltered := make([]string, 0, len(filtered))
+			for _, pod := range filtered {
+				gotFiltered = append(gotFiltered, pod.Name)
+			}
+			if !stringSlicesEqual(gotFiltered, tt.wantFiltered) {
+				t.Errorf("filtered = %v, want %v", gotFiltered, tt.wantFiltered)
+			}
+
+			gotSkipped := make([]string, 0, len(skipped))
+			for _, result := range skipped {
+				gotSkipped = append(gotSkipped, result.Name)
+			}
+			if !stringSlicesEqual(gotSkipped, tt.wantSkipped) {
+				t.Errorf("skipped = %v, want %v", gotSkipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// EvictResult is the structured outcome of acting on a single pod, used as the row format for
+// the evict command's table (default), JSON, YAML, jsonpath and go-template output.
+type EvictResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	UID       string `json:"uid"`
+	Phase     string `json:"phase,omitempty"`
+	Action    string `json:"action"`
+	Reason    string `json:"reason,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// toUnstructured converts r to an *unstructured.Unstructured so it can be handed to any
+// genericclioptions.PrintFlags printer (JSON, YAML, jsonpath, go-template) without r having
+// to satisfy runtime.Object itself. The object is stamped with an apiVersion and kind, which
+// those printers refuse to print without.
+func (r EvictResult) toUnstructured() (*unstructured.Unstructured, error) {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&r)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: m}
+	u.SetAPIVersion("kubectl-evict/v1")
+	u.SetKind("EvictResult")
+	return u, nil
+}